@@ -0,0 +1,99 @@
+package powerpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUndefinedLabel is returned by Assemble when a branch refers to a
+// label that was never defined via Label.
+var ErrUndefinedLabel = errors.New("branch refers to an undefined label")
+
+// BranchOp identifies which branch mnemonic EmitBranch should resolve a
+// label into once its address is known.
+type BranchOp int
+
+const (
+	// OpB represents an unconditional branch, as emitted by B.
+	OpB BranchOp = iota
+	// OpBL represents a branch-and-link, as emitted by BL.
+	OpBL
+	// OpBNE represents a branch-if-not-equal, as emitted by BNE.
+	OpBNE
+)
+
+// pendingBranch records a branch awaiting its target's resolved address.
+type pendingBranch struct {
+	index int
+	op    BranchOp
+	label string
+}
+
+// Assembler builds a contiguous run of instructions that may reference
+// labels before they are defined, resolving them once the run's base
+// address is known. This makes it possible to write trampolines and other
+// injected code without hand-computing every branch offset.
+type Assembler struct {
+	instructions []Instruction
+	labels       map[string]int
+	pending      []pendingBranch
+}
+
+// NewAssembler returns an empty Assembler ready to accept instructions.
+func NewAssembler() *Assembler {
+	return &Assembler{
+		labels: make(map[string]int),
+	}
+}
+
+// Label marks the current position within the instruction stream as name,
+// so that a later EmitBranch call may branch to it.
+func (a *Assembler) Label(name string) {
+	a.labels[name] = len(a.instructions)
+}
+
+// Emit appends a fully-formed instruction to the stream.
+func (a *Assembler) Emit(inst Instruction) {
+	a.instructions = append(a.instructions, inst)
+}
+
+// EmitBranch reserves a slot for a branch to label, to be resolved once
+// Assemble knows every label's address. label may be defined either
+// before or after this call.
+func (a *Assembler) EmitBranch(op BranchOp, label string) {
+	a.pending = append(a.pending, pendingBranch{
+		index: len(a.instructions),
+		op:    op,
+		label: label,
+	})
+	a.instructions = append(a.instructions, Padding)
+}
+
+// Assemble performs two-pass resolution of the instruction stream and
+// returns its encoded bytes. In the first pass, Label and EmitBranch have
+// already recorded each label's offset from baseAddr and reserved space
+// for each pending branch. The second pass, performed here, rewrites
+// those reserved slots by calling calcDestination with the now-resolved
+// from/target address pair.
+func (a *Assembler) Assemble(baseAddr uint) ([]byte, error) {
+	for _, branch := range a.pending {
+		targetIndex, ok := a.labels[branch.label]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUndefinedLabel, branch.label)
+		}
+
+		from := baseAddr + uint(branch.index*4)
+		target := baseAddr + uint(targetIndex*4)
+
+		switch branch.op {
+		case OpBL:
+			a.instructions[branch.index] = BL(from, target)
+		case OpBNE:
+			a.instructions[branch.index] = BNE(from, target)
+		default:
+			a.instructions[branch.index] = B(from, target)
+		}
+	}
+
+	return Instructions(a.instructions).Bytes(), nil
+}
@@ -0,0 +1,265 @@
+package powerpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownInstruction is returned when a 32-bit word does not match
+// any entry within the opcode table.
+var ErrUnknownInstruction = errors.New("instruction did not match any known encoding")
+
+// ArgType describes how a single operand of a decoded instruction
+// should be interpreted once its bits have been extracted.
+type ArgType int
+
+const (
+	// TypeReg is a general-purpose register, e.g. r3.
+	TypeReg ArgType = iota
+	// TypeImm is an unsigned immediate value.
+	TypeImm
+	// TypeSImm is a signed immediate value.
+	TypeSImm
+	// TypeSpecialReg is a special-purpose register number, as used by mtspr/mfspr.
+	TypeSpecialReg
+	// TypeOffset is a signed, word-aligned branch displacement.
+	TypeOffset
+	// TypeCondReg is a condition register field.
+	TypeCondReg
+)
+
+// BitFields holds one or more bit ranges that, concatenated most-significant
+// chunk first, make up a single operand. Most operands only need one entry -
+// multi-field encodings, such as mtspr/mfspr's split 5+5 SPR number, need two.
+type BitFields []struct {
+	Offset uint8
+	Width  uint8
+}
+
+// argField describes how to extract and interpret a single operand from
+// a 32-bit instruction.
+type argField struct {
+	Type   ArgType
+	Shift  uint8
+	Fields BitFields
+}
+
+// instFormat describes a single opcode entry: matching is performed via
+// `ins & Mask == Value`, and Args describes how to pull operands from the
+// remaining bits in the order they should be printed.
+type instFormat struct {
+	Op    string
+	Mask  uint32
+	Value uint32
+	Args  []argField
+}
+
+// bitRange extracts width bits starting at offset, where offset 0 is the
+// most-significant bit of the instruction - matching the bit numbering
+// used throughout the PowerPC ISA manual.
+func bitRange(ins uint32, offset, width uint8) uint32 {
+	shift := 32 - offset - width
+	mask := uint32(1)<<width - 1
+	return (ins >> shift) & mask
+}
+
+// extractArg pulls the raw operand value described by field out of ins,
+// concatenating multiple BitFields most-significant chunk first.
+func extractArg(ins uint32, field argField) uint32 {
+	var value uint32
+	for _, bf := range field.Fields {
+		value = value<<bf.Width | bitRange(ins, bf.Offset, bf.Width)
+	}
+	return value << field.Shift
+}
+
+// totalWidth returns the bit width of the fully-concatenated operand,
+// including the left shift applied afterward - used to sign-extend it.
+func totalWidth(field argField) uint8 {
+	var width uint8
+	for _, bf := range field.Fields {
+		width += bf.Width
+	}
+	return width + field.Shift
+}
+
+// bits is shorthand for a single-field BitFields entry.
+func bits(offset, width uint8) BitFields {
+	return BitFields{{Offset: offset, Width: width}}
+}
+
+// dForm describes the rT, rA, imm operands shared by D-form instructions.
+func dForm(signed bool) []argField {
+	immType := TypeImm
+	if signed {
+		immType = TypeSImm
+	}
+	return []argField{
+		{Type: TypeReg, Fields: bits(6, 5)},
+		{Type: TypeReg, Fields: bits(11, 5)},
+		{Type: immType, Fields: bits(16, 16)},
+	}
+}
+
+// opcodeTable enumerates every instruction encoding recognized by this
+// package, in the table-driven style of golang.org/x/arch/ppc64/ppc64asm.
+var opcodeTable = []instFormat{
+	{Op: "blr", Mask: 0xFFFFFFFF, Value: 0x4E800020},
+	{Op: "eieio", Mask: 0xFFFFFFFF, Value: 0x7C0006AC},
+	{Op: "sync", Mask: 0xFC0007FE, Value: 31<<26 | 598<<1, Args: []argField{
+		{Type: TypeImm, Fields: bits(9, 2)},
+	}},
+	{Op: "crxor", Mask: 0xFC0007FF, Value: 19<<26 | 193<<1, Args: []argField{
+		{Type: TypeCondReg, Fields: bits(6, 5)},
+		{Type: TypeCondReg, Fields: bits(11, 5)},
+		{Type: TypeCondReg, Fields: bits(16, 5)},
+	}},
+	{Op: "mulli", Mask: 0xFC000000, Value: 7 << 26, Args: dForm(true)},
+	{Op: "bclr", Mask: 0xFC0007FE, Value: 19<<26 | 16<<1, Args: []argField{
+		{Type: TypeImm, Fields: bits(6, 5)},
+		{Type: TypeImm, Fields: bits(11, 5)},
+		{Type: TypeImm, Fields: bits(31, 1)},
+	}},
+	{Op: "bcctr", Mask: 0xFC0007FE, Value: 19<<26 | 528<<1, Args: []argField{
+		{Type: TypeImm, Fields: bits(6, 5)},
+		{Type: TypeImm, Fields: bits(11, 5)},
+		{Type: TypeImm, Fields: bits(31, 1)},
+	}},
+	{Op: "rlwinm", Mask: 0xFC000001, Value: 21 << 26, Args: []argField{
+		{Type: TypeReg, Fields: bits(11, 5)},
+		{Type: TypeReg, Fields: bits(6, 5)},
+		{Type: TypeImm, Fields: bits(16, 5)},
+		{Type: TypeImm, Fields: bits(21, 5)},
+		{Type: TypeImm, Fields: bits(26, 5)},
+	}},
+	{Op: "mtcrf", Mask: 0xFC1007FF, Value: 31<<26 | 144<<1, Args: []argField{
+		{Type: TypeImm, Fields: bits(12, 8)},
+		{Type: TypeReg, Fields: bits(6, 5)},
+	}},
+	{Op: "addi", Mask: 0xFC000000, Value: 14 << 26, Args: dForm(true)},
+	{Op: "addis", Mask: 0xFC000000, Value: 15 << 26, Args: dForm(true)},
+	{Op: "ori", Mask: 0xFC000000, Value: 24 << 26, Args: dForm(false)},
+	{Op: "sth", Mask: 0xFC000000, Value: 44 << 26, Args: dForm(false)},
+	{Op: "stw", Mask: 0xFC000000, Value: 36 << 26, Args: dForm(false)},
+	{Op: "lwz", Mask: 0xFC000000, Value: 32 << 26, Args: dForm(false)},
+	{Op: "stwu", Mask: 0xFC000000, Value: 37 << 26, Args: dForm(false)},
+	{Op: "cmpwi", Mask: 0xFC000000, Value: 11 << 26, Args: []argField{
+		{Type: TypeReg, Fields: bits(11, 5)},
+		{Type: TypeSImm, Fields: bits(16, 16)},
+	}},
+	{Op: "or", Mask: 0xFC0007FF, Value: 31<<26 | 444<<1, Args: []argField{
+		{Type: TypeReg, Fields: bits(11, 5)},
+		{Type: TypeReg, Fields: bits(6, 5)},
+		{Type: TypeReg, Fields: bits(16, 5)},
+	}},
+	{Op: "mtspr", Mask: 0xFC0007FF, Value: 31<<26 | 467<<1, Args: []argField{
+		{Type: TypeSpecialReg, Fields: BitFields{{Offset: 16, Width: 5}, {Offset: 11, Width: 5}}},
+		{Type: TypeReg, Fields: bits(6, 5)},
+	}},
+	{Op: "mfspr", Mask: 0xFC0007FF, Value: 31<<26 | 339<<1, Args: []argField{
+		{Type: TypeReg, Fields: bits(6, 5)},
+		{Type: TypeSpecialReg, Fields: BitFields{{Offset: 16, Width: 5}, {Offset: 11, Width: 5}}},
+	}},
+	{Op: "bl", Mask: 0xFC000003, Value: 18<<26 | 0<<1 | 1, Args: []argField{
+		{Type: TypeOffset, Shift: 2, Fields: bits(6, 24)},
+	}},
+	{Op: "b", Mask: 0xFC000003, Value: 18 << 26, Args: []argField{
+		{Type: TypeOffset, Shift: 2, Fields: bits(6, 24)},
+	}},
+	{Op: "bne", Mask: 0xFFFF0003, Value: 16<<26 | 4<<21 | 2<<16, Args: []argField{
+		{Type: TypeOffset, Shift: 2, Fields: bits(16, 14)},
+	}},
+}
+
+// DecodedInstruction is the structured form of a single decoded instruction.
+type DecodedInstruction struct {
+	// Mnemonic is the instruction's opcode name, e.g. "addi".
+	Mnemonic string
+	// Args holds the instruction's operands, formatted for display.
+	Args []string
+	// Raw is the original, undecoded instruction.
+	Raw Instruction
+}
+
+// String formats a DecodedInstruction as "mnemonic op1, op2, ...".
+func (d DecodedInstruction) String() string {
+	if len(d.Args) == 0 {
+		return d.Mnemonic
+	}
+	return d.Mnemonic + " " + strings.Join(d.Args, ", ")
+}
+
+// formatArg renders a single extracted operand as PowerPC assembly text.
+func formatArg(ins uint32, field argField) string {
+	raw := extractArg(ins, field)
+
+	switch field.Type {
+	case TypeReg:
+		return fmt.Sprintf("r%d", raw)
+	case TypeCondReg:
+		return fmt.Sprintf("cr%d", raw)
+	case TypeSpecialReg:
+		return fmt.Sprintf("spr%d", raw)
+	case TypeImm:
+		return fmt.Sprintf("0x%X", raw)
+	case TypeSImm, TypeOffset:
+		width := totalWidth(field)
+		signed := int32(raw)
+		if raw&(1<<(width-1)) != 0 {
+			signed = int32(raw) - int32(1<<width)
+		}
+		return fmt.Sprintf("%d", signed)
+	default:
+		return fmt.Sprintf("0x%X", raw)
+	}
+}
+
+// DecodeInstruction decodes a single 4-byte PowerPC instruction, returning
+// its structured form alongside its formatted assembly text.
+func DecodeInstruction(data []byte) (DecodedInstruction, string, error) {
+	if len(data) != 4 {
+		return DecodedInstruction{}, "", fmt.Errorf("instruction must be exactly 4 bytes, got %d", len(data))
+	}
+
+	ins := binary.BigEndian.Uint32(data)
+
+	for _, format := range opcodeTable {
+		if ins&format.Mask != format.Value {
+			continue
+		}
+
+		decoded := DecodedInstruction{
+			Mnemonic: format.Op,
+			Raw:      Instruction{data[0], data[1], data[2], data[3]},
+		}
+		for _, arg := range format.Args {
+			decoded.Args = append(decoded.Args, formatArg(ins, arg))
+		}
+
+		return decoded, decoded.String(), nil
+	}
+
+	return DecodedInstruction{}, "", ErrUnknownInstruction
+}
+
+// Disassemble decodes every 4-byte instruction within binary in order.
+// It stops and returns an error upon encountering unrecognized contents,
+// rather than silently skipping them.
+func Disassemble(binary []byte) ([]DecodedInstruction, error) {
+	if len(binary)%4 != 0 {
+		return nil, errors.New("binary length must be a multiple of four")
+	}
+
+	instructions := make([]DecodedInstruction, 0, len(binary)/4)
+	for offset := 0; offset < len(binary); offset += 4 {
+		decoded, _, err := DecodeInstruction(binary[offset : offset+4])
+		if err != nil {
+			return nil, fmt.Errorf("at offset 0x%X: %w", offset, err)
+		}
+		instructions = append(instructions, decoded)
+	}
+
+	return instructions, nil
+}
@@ -0,0 +1,14 @@
+package powerpc
+
+import "testing"
+
+func TestDecodeCmpwiNonzeroRA(t *testing.T) {
+	inst := CMPWI(3, 5)
+	decoded, _, err := DecodeInstruction(inst[:])
+	if err != nil {
+		t.Fatalf("DecodeInstruction(%x): %v", inst, err)
+	}
+	if decoded.Mnemonic != "cmpwi" {
+		t.Fatalf("decoded as %q, want cmpwi", decoded.Mnemonic)
+	}
+}
@@ -0,0 +1,38 @@
+package powerpc
+
+import "encoding/binary"
+
+// EncodeInstrXLForm encodes an XL-form instruction: an opcode, three
+// 5-bit fields (condition register bits for crxor, or BO/BI for the
+// branch-conditional forms bclr/bcctr), an extended opcode, and a link bit.
+func EncodeInstrXLForm(op int, f1 uint8, f2 uint8, f3 uint8, xo int, lk bool) Instruction {
+	var out Instruction
+	var lkBit uint32
+	if lk {
+		lkBit = 1
+	}
+	binary.BigEndian.PutUint32(out[:], uint32(op)<<26|uint32(f1)<<21|uint32(f2)<<16|uint32(f3)<<11|uint32(xo)<<1|lkBit)
+	return out
+}
+
+// EncodeInstrMForm encodes an M-form instruction, as used by rotate/mask
+// instructions such as rlwinm: an opcode, rS, rA, a shift amount, a mask's
+// begin/end bits, and the record bit.
+func EncodeInstrMForm(op int, rS Register, rA Register, sh uint8, mb uint8, me uint8, rc bool) Instruction {
+	var out Instruction
+	var rcBit uint32
+	if rc {
+		rcBit = 1
+	}
+	binary.BigEndian.PutUint32(out[:], uint32(op)<<26|uint32(rS)<<21|uint32(rA)<<16|uint32(sh)<<11|uint32(mb)<<6|uint32(me)<<1|rcBit)
+	return out
+}
+
+// EncodeInstrXFXCRMForm encodes the mtcrf instruction's XFX-form variant,
+// which moves rS into the condition register fields selected by the
+// 8-bit CRM mask rather than into a special-purpose register.
+func EncodeInstrXFXCRMForm(op int, rS Register, crm uint8, xo int) Instruction {
+	var out Instruction
+	binary.BigEndian.PutUint32(out[:], uint32(op)<<26|uint32(rS)<<21|uint32(crm)<<12|uint32(xo)<<1)
+	return out
+}
@@ -0,0 +1,268 @@
+package powerpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Gecko and Ocarina/Action Replay codes describe writes relative to a
+// game's loaded address space, typically starting at 0x80000000. The
+// loadBase parameter of the parsers converts those virtual addresses into file offsets within the binary
+// being patched.
+const geckoVirtualBase = 0x80000000
+
+var (
+	// ErrMalformedCode is returned when a cheat code line cannot be parsed.
+	ErrMalformedCode = errors.New("malformed cheat code line")
+	// ErrUnsupportedCodeType is returned for a recognized-but-unhandled Gecko code type.
+	ErrUnsupportedCodeType = errors.New("unsupported Gecko code type")
+)
+
+// addrToOffset converts a Gecko-style virtual address into a file offset
+// within a binary loaded at loadBase.
+func addrToOffset(addr uint32, loadBase uint32) int {
+	return int(geckoVirtualBase|addr&0x01FFFFFF) - int(loadBase)
+}
+
+// ParseGeckoCodes parses the Gecko-format cheat codes read from r into
+// one PatchSet per code. original is the unmodified file the codes are
+// written against - it is needed to capture each patch's
+// Before bytes, and loadBase converts the codes' virtual addresses into
+// offsets within it.
+//
+// The 04 (32-bit write), 06 (memory copy) and C2 (branch insertion) code
+// types are supported; any other type present returns ErrUnsupportedCodeType.
+func ParseGeckoCodes(r io.Reader, original []byte, loadBase uint32) ([]PatchSet, error) {
+	lines, err := readHexLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []PatchSet
+	// appendedLen tracks how many bytes prior C2 code caves within this
+	// call have already appended, so multiple C2 codes don't collide.
+	appendedLen := 0
+	for i := 0; i < len(lines); {
+		first := lines[i]
+		if len(first) != 2 {
+			return nil, fmt.Errorf("%w: expected two 32-bit words, got %d", ErrMalformedCode, len(first))
+		}
+
+		codeType := byte(first[0] >> 24)
+		addr := first[0] &^ (0xFF << 24)
+
+		switch codeType {
+		case 0x04:
+			offset := addrToOffset(addr, loadBase)
+			if offset < 0 || offset+4 > len(original) {
+				return nil, ErrPatchOutOfRange
+			}
+			sets = append(sets, PatchSet{
+				Name: fmt.Sprintf("gecko write at 0x%08X", geckoVirtualBase|addr),
+				Patches: []Patch{{
+					AtOffset: offset,
+					Before:   append([]byte{}, original[offset:offset+4]...),
+					After:    fourByte(first[1]),
+				}},
+			})
+			i++
+
+		case 0x06:
+			offset := addrToOffset(addr, loadBase)
+			length := int(first[1])
+			wordCount := (length + 7) / 8
+			if i+1+wordCount > len(lines) {
+				return nil, fmt.Errorf("%w: truncated 06 code", ErrMalformedCode)
+			}
+
+			var after []byte
+			for _, word := range lines[i+1 : i+1+wordCount] {
+				after = append(after, fourByte(word[0])...)
+				after = append(after, fourByte(word[1])...)
+			}
+			after = after[:length]
+
+			if offset < 0 || offset+length > len(original) {
+				return nil, ErrPatchOutOfRange
+			}
+			sets = append(sets, PatchSet{
+				Name: fmt.Sprintf("gecko copy at 0x%08X", geckoVirtualBase|addr),
+				Patches: []Patch{{
+					AtOffset: offset,
+					Before:   append([]byte{}, original[offset:offset+length]...),
+					After:    after,
+				}},
+			})
+			i += 1 + wordCount
+
+		case 0xC2:
+			offset := addrToOffset(addr, loadBase)
+			wordCount := int(first[1])
+			if i+1+wordCount > len(lines) {
+				return nil, fmt.Errorf("%w: truncated C2 code", ErrMalformedCode)
+			}
+			if offset < 0 || offset+4 > len(original) {
+				return nil, ErrPatchOutOfRange
+			}
+
+			// The inserted instructions are placed in a code cave appended
+			// after the rest of the binary; the call site is replaced with
+			// a branch to it, and the cave itself branches back afterward.
+			// The cave is appended rather than written AtOffset, as the
+			// binary has no existing bytes there yet for ApplyPatch to
+			// find and overwrite.
+			callAddr := uint(geckoVirtualBase | addr)
+			caveOffset := len(original) + appendedLen
+			caveAddr := uint(loadBase) + uint(caveOffset)
+
+			var cave Instructions
+			for _, word := range lines[i+1 : i+1+wordCount] {
+				var a, b Instruction
+				binary.BigEndian.PutUint32(a[:], word[0])
+				binary.BigEndian.PutUint32(b[:], word[1])
+				cave = append(cave, a, b)
+			}
+			returnBranchFrom := caveAddr + uint(len(cave)*4)
+			cave = append(cave, B(returnBranchFrom, callAddr+4))
+
+			callSite := BL(callAddr, caveAddr)
+			sets = append(sets, PatchSet{
+				Name: fmt.Sprintf("gecko branch insertion at 0x%08X", geckoVirtualBase|addr),
+				Patches: []Patch{
+					{
+						AtOffset: offset,
+						Before:   append([]byte{}, original[offset:offset+4]...),
+						After:    callSite[:],
+					},
+					{
+						Append: true,
+						After:  cave.Bytes(),
+					},
+				},
+			})
+			appendedLen += len(cave.Bytes())
+			i += 1 + wordCount
+
+		default:
+			return nil, fmt.Errorf("%w: 0x%02X", ErrUnsupportedCodeType, codeType)
+		}
+	}
+
+	return sets, nil
+}
+
+// FormatGeckoCodes renders PatchSets containing simple 32-bit writes back
+// into Gecko's "04 XXXXXX YYYYYYYY" text form. loadBase converts each
+// patch's AtOffset - a file offset - back into the virtual address it was
+// parsed from, inverting addrToOffset; it must match the loadBase passed
+// to ParseGeckoCodes for the round trip to produce the original addresses.
+// Appended patches (code caves) and patches that are not a plain 4-byte
+// write are skipped, as Gecko's 04 code has no way to express them.
+func FormatGeckoCodes(sets []PatchSet, loadBase uint32) string {
+	var b strings.Builder
+	for _, set := range sets {
+		for _, patch := range set.Patches {
+			if patch.Append || len(patch.After) != 4 {
+				continue
+			}
+			addr := (uint32(patch.AtOffset) + loadBase) & 0x01FFFFFF
+			value := binary.BigEndian.Uint32(patch.After)
+			fmt.Fprintf(&b, "04%06X %08X\n", addr, value)
+		}
+	}
+	return b.String()
+}
+
+// ParseOcarinaCodes parses the older Action Replay/Ocarina format, where
+// every line is a bare "XXXXXXXX YYYYYYYY" 32-bit write with no leading
+// command byte - equivalent to a Gecko 04 code without its type nibble.
+func ParseOcarinaCodes(r io.Reader, original []byte, loadBase uint32) ([]PatchSet, error) {
+	lines, err := readHexLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []PatchSet
+	for _, line := range lines {
+		if len(line) != 2 {
+			return nil, fmt.Errorf("%w: expected two 32-bit words, got %d", ErrMalformedCode, len(line))
+		}
+
+		addr := line[0] &^ (0xFF << 24)
+		offset := addrToOffset(addr, loadBase)
+		if offset < 0 || offset+4 > len(original) {
+			return nil, ErrPatchOutOfRange
+		}
+
+		sets = append(sets, PatchSet{
+			Name: fmt.Sprintf("ocarina write at 0x%08X", geckoVirtualBase|addr),
+			Patches: []Patch{{
+				AtOffset: offset,
+				Before:   append([]byte{}, original[offset:offset+4]...),
+				After:    fourByte(line[1]),
+			}},
+		})
+	}
+
+	return sets, nil
+}
+
+// FormatOcarinaCodes renders PatchSets containing simple 32-bit writes
+// back into the Action Replay/Ocarina "XXXXXXXX YYYYYYYY" text form.
+// loadBase inverts addrToOffset the same way as FormatGeckoCodes, and
+// must match the loadBase passed to ParseOcarinaCodes.
+func FormatOcarinaCodes(sets []PatchSet, loadBase uint32) string {
+	var b strings.Builder
+	for _, set := range sets {
+		for _, patch := range set.Patches {
+			if patch.Append || len(patch.After) != 4 {
+				continue
+			}
+			addr := geckoVirtualBase | (uint32(patch.AtOffset)+loadBase)&0x01FFFFFF
+			value := binary.BigEndian.Uint32(patch.After)
+			fmt.Fprintf(&b, "%08X %08X\n", addr, value)
+		}
+	}
+	return b.String()
+}
+
+// readHexLines reads every non-empty, non-comment line from r and parses
+// it as a pair of 8-digit hexadecimal 32-bit words.
+func readHexLines(r io.Reader) ([][2]uint32, error) {
+	var lines [][2]uint32
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "*") || strings.HasPrefix(line, "$") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: %q", ErrMalformedCode, line)
+		}
+
+		var words [2]uint32
+		for i, field := range fields {
+			raw, err := hex.DecodeString(field)
+			if err != nil || len(raw) != 4 {
+				return nil, fmt.Errorf("%w: %q", ErrMalformedCode, line)
+			}
+			words[i] = binary.BigEndian.Uint32(raw)
+		}
+
+		lines = append(lines, words)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,47 @@
+package powerpc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGeckoCodesBranchInsertionAppendsCave(t *testing.T) {
+	original := make([]byte, 0x20)
+	const loadBase = 0x80000000
+
+	r := strings.NewReader("C2000010 00000001\n38600005 00000000\n")
+	sets, err := ParseGeckoCodes(r, original, loadBase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binary := append([]byte{}, original...)
+	for _, set := range sets {
+		var err error
+		var report PatchReport
+		binary, report, err = ApplyPatchSet(set, binary, ApplyOptions{})
+		if err != nil {
+			t.Fatalf("ApplyPatchSet: %v (report=%+v)", err, report)
+		}
+	}
+
+	if len(binary) <= len(original) {
+		t.Fatalf("expected cave to grow the binary, got len %d", len(binary))
+	}
+}
+
+func TestFormatGeckoCodesRoundTripsNonDefaultLoadBase(t *testing.T) {
+	original := make([]byte, 0x20)
+	const loadBase = 0x80000100
+
+	const code = "04000110 38600005\n"
+	sets, err := ParseGeckoCodes(strings.NewReader(code), original, loadBase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := FormatGeckoCodes(sets, loadBase)
+	if !strings.EqualFold(strings.TrimSpace(got), strings.TrimSpace(code)) {
+		t.Fatalf("FormatGeckoCodes(%08X) = %q, want %q", loadBase, got, code)
+	}
+}
@@ -0,0 +1,225 @@
+// Command gen reads a CSV opcode table and emits a Go source file
+// containing one encoder function per row. It is invoked via
+// `go generate` from the package root - see the directive atop powerpc.go.
+//
+// Only instruction forms whose operands can be fully described by an
+// opcode and an extended opcode are table-driven here. Forms that need
+// runtime address resolution (I-form and B-form branches, via
+// calcDestination) remain hand-written in powerpc.go.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// opcodeRow mirrors a single row of the CSV opcode table.
+type opcodeRow struct {
+	Mnemonic string
+	Form     string
+	Opcode   string
+	XO       string
+	Signed   string
+	Summary  string
+	Example  string
+}
+
+// Title is the row's mnemonic, upper-cased to match this package's
+// exported instruction constructors (e.g. ADDI, MULLI).
+func (r opcodeRow) Title() string {
+	return strings.ToUpper(r.Mnemonic)
+}
+
+const fileHeader = `// Code generated by go generate from opcodes.csv; DO NOT EDIT.
+
+package powerpc
+`
+
+const docTemplate = `
+// {{.Title}} represents the {{.Mnemonic}} PowerPC instruction.
+// It {{.Summary}}.
+`
+
+const dFormTemplate = `func {{.Title}}(rT Register, rA Register, value uint16) Instruction {
+	return EncodeInstrDForm({{.Opcode}}, rT, rA, value)
+}
+`
+
+// dFormSignedTemplate is used instead of dFormTemplate when a row's Signed
+// column is true, so the immediate is taken as a signed value and
+// sign-extended into the D-form's 16-bit field rather than truncated.
+const dFormSignedTemplate = `func {{.Title}}(rT Register, rA Register, value int16) Instruction {
+	return EncodeInstrDForm({{.Opcode}}, rT, rA, uint16(value))
+}
+`
+
+const xlFormTemplate = `func {{.Title}}(crbD uint8, crbA uint8, crbB uint8) Instruction {
+	return EncodeInstrXLForm({{.Opcode}}, crbD, crbA, crbB, {{.XO}}, false)
+}
+`
+
+const xlbrFormTemplate = `func {{.Title}}(bo uint8, bi uint8, lk bool) Instruction {
+	return EncodeInstrXLForm({{.Opcode}}, bo, bi, 0, {{.XO}}, lk)
+}
+`
+
+const mFormTemplate = `func {{.Title}}(rS Register, rA Register, sh uint8, mb uint8, me uint8, rc bool) Instruction {
+	return EncodeInstrMForm({{.Opcode}}, rS, rA, sh, mb, me, rc)
+}
+`
+
+const xfxcrmFormTemplate = `func {{.Title}}(rS Register, crm uint8) Instruction {
+	return EncodeInstrXFXCRMForm({{.Opcode}}, rS, crm, {{.XO}})
+}
+`
+
+const testFileHeader = `// Code generated by go generate from opcodes.csv; DO NOT EDIT.
+
+package powerpc
+
+import "testing"
+
+// generatedRoundTrips pairs each table-generated instruction with a
+// representative call, confirming it decodes back to its own mnemonic.
+var generatedRoundTrips = []struct {
+	mnemonic string
+	inst     Instruction
+}{
+`
+
+const testFileFooter = `}
+
+func TestGeneratedInstructionsRoundTrip(t *testing.T) {
+	for _, tt := range generatedRoundTrips {
+		decoded, _, err := DecodeInstruction(tt.inst[:])
+		if err != nil {
+			t.Errorf("%s: DecodeInstruction failed: %v", tt.mnemonic, err)
+			continue
+		}
+		if decoded.Mnemonic != tt.mnemonic {
+			t.Errorf("%s: decoded as %q", tt.mnemonic, decoded.Mnemonic)
+		}
+	}
+}
+`
+
+const testCaseTemplate = `	{"{{.Mnemonic}}", {{.Title}}({{.Example}})},
+`
+
+// formTemplates maps a CSV row's Form column to the body template used
+// to render its encoder function.
+var formTemplates = map[string]string{
+	"D":      dFormTemplate,
+	"XL":     xlFormTemplate,
+	"XLBR":   xlbrFormTemplate,
+	"M":      mFormTemplate,
+	"XFXCRM": xfxcrmFormTemplate,
+}
+
+// bodyTemplate returns the body template used to render row's encoder
+// function, honoring the Signed column for D-form rows so a signed
+// immediate is sign-extended rather than taken as-is.
+func bodyTemplate(row opcodeRow) (string, error) {
+	if row.Form == "D" && row.Signed == "true" {
+		return dFormSignedTemplate, nil
+	}
+
+	tmpl, ok := formTemplates[row.Form]
+	if !ok {
+		return "", fmt.Errorf("%s: unrecognized form %q", row.Mnemonic, row.Form)
+	}
+	return tmpl, nil
+}
+
+func main() {
+	csvPath := flag.String("csv", "opcodes.csv", "path to the CSV opcode table")
+	outPath := flag.String("out", "zinstructions.go", "path to write the generated Go source to")
+	testOutPath := flag.String("test-out", "zinstructions_test.go", "path to write the generated round-trip tests to")
+	flag.Parse()
+
+	rows, err := readOpcodes(*csvPath)
+	if err != nil {
+		log.Fatalf("gen: %v", err)
+	}
+
+	doc := template.Must(template.New("doc").Parse(docTemplate))
+	testCase := template.Must(template.New("testCase").Parse(testCaseTemplate))
+
+	var body, tests strings.Builder
+	for _, row := range rows {
+		bodyTmpl, err := bodyTemplate(row)
+		if err != nil {
+			log.Fatalf("gen: %v", err)
+		}
+
+		if err := doc.Execute(&body, row); err != nil {
+			log.Fatalf("gen: %v", err)
+		}
+		if err := template.Must(template.New(row.Form).Parse(bodyTmpl)).Execute(&body, row); err != nil {
+			log.Fatalf("gen: %v", err)
+		}
+		if err := testCase.Execute(&tests, row); err != nil {
+			log.Fatalf("gen: %v", err)
+		}
+	}
+
+	if err := writeFile(*outPath, fileHeader+body.String()); err != nil {
+		log.Fatalf("gen: %v", err)
+	}
+	if err := writeFile(*testOutPath, testFileHeader+tests.String()+testFileFooter); err != nil {
+		log.Fatalf("gen: %v", err)
+	}
+}
+
+func writeFile(path string, contents string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = out.WriteString(contents)
+	return err
+}
+
+// readOpcodes parses the CSV opcode table at path into a slice of rows,
+// skipping its header.
+func readOpcodes(path string) ([]opcodeRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 1 {
+		return nil, fmt.Errorf("%s: empty opcode table", path)
+	}
+
+	var rows []opcodeRow
+	for _, record := range records[1:] {
+		if len(record) != 7 {
+			return nil, fmt.Errorf("%s: expected 7 columns, got %d", path, len(record))
+		}
+		rows = append(rows, opcodeRow{
+			Mnemonic: record[0],
+			Form:     record[1],
+			Opcode:   record[2],
+			XO:       record[3],
+			Signed:   record[4],
+			Summary:  record[5],
+			Example:  record[6],
+		})
+	}
+
+	return rows, nil
+}
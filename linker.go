@@ -0,0 +1,135 @@
+package powerpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUndefinedSymbol is returned by Link when a relocation or call site
+// refers to a symbol an Object does not export.
+var ErrUndefinedSymbol = errors.New("relocation refers to an undefined symbol")
+
+// RelocKind identifies how a Reloc's value should be computed and written
+// back into an Object's code.
+type RelocKind int
+
+const (
+	// RelBL resolves a branch-and-link to the symbol's address, matching
+	// the PowerPC R_PPC_REL24 relocation.
+	RelBL RelocKind = iota
+	// RelB resolves an unconditional branch to the symbol's address,
+	// also an R_PPC_REL24 relocation.
+	RelB
+	// RelHi16 writes the high, carry-adjusted 16 bits of the symbol's
+	// address, as consumed by a following lis - R_PPC_ADDR16_HA.
+	RelHi16
+	// RelLo16 writes the low 16 bits of the symbol's address, as consumed
+	// by a following addi/ori - R_PPC_ADDR16_LO.
+	RelLo16
+)
+
+// Reloc describes a single location within an Object's code that must be
+// rewritten once the object's final address is known.
+type Reloc struct {
+	// Kind selects how Offset should be rewritten.
+	Kind RelocKind
+
+	// Offset is the byte offset within Object.Code to rewrite.
+	Offset int
+
+	// Symbol is the name of the Object symbol this relocation targets.
+	Symbol string
+}
+
+// Object is a self-contained blob of assembled PowerPC code, along with
+// everything needed to relocate it once it is placed somewhere in a binary.
+type Object struct {
+	// Code is the object's assembled instructions.
+	Code []byte
+
+	// Relocs lists every location within Code that must be resolved
+	// against Symbols once Code's final address is known.
+	Relocs []Reloc
+
+	// Symbols maps a symbol name to its byte offset within Code.
+	Symbols map[string]int
+}
+
+// CallSite is a location within a binary that should be rewritten to
+// branch into a linked Object.
+type CallSite struct {
+	// Offset is the location within the binary to overwrite.
+	Offset int
+
+	// Symbol is the Object symbol the call site should branch to.
+	Symbol string
+}
+
+// Link places obj.Code at caveOffset within binary, resolves every
+// relocation in obj.Relocs against obj.Symbols, and rewrites every
+// CallSite to branch into the newly-placed code - the equivalent of what
+// a linker's relocation pass does for code injected into an existing binary.
+//
+// binary and caveOffset are treated as the same address space: offsets
+// within binary are assumed to already be the addresses instructions will
+// execute at, matching how BL and B are used elsewhere in this package.
+func Link(binary []byte, caveOffset int, obj Object, patches []CallSite) ([]byte, error) {
+	if caveOffset < 0 || caveOffset > len(binary) {
+		return nil, ErrPatchOutOfRange
+	}
+
+	cave := append([]byte{}, obj.Code...)
+
+	for _, reloc := range obj.Relocs {
+		symOffset, ok := obj.Symbols[reloc.Symbol]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUndefinedSymbol, reloc.Symbol)
+		}
+		if reloc.Offset+4 > len(cave) {
+			return nil, ErrPatchOutOfRange
+		}
+
+		from := uint(caveOffset + reloc.Offset)
+		target := uint(caveOffset + symOffset)
+
+		switch reloc.Kind {
+		case RelBL:
+			inst := BL(from, target)
+			copy(cave[reloc.Offset:], inst[:])
+		case RelB:
+			inst := B(from, target)
+			copy(cave[reloc.Offset:], inst[:])
+		case RelHi16:
+			ha := uint16((uint32(target) + 0x8000) >> 16)
+			cave[reloc.Offset+2] = byte(ha >> 8)
+			cave[reloc.Offset+3] = byte(ha)
+		case RelLo16:
+			lo := uint16(uint32(target) & 0xFFFF)
+			cave[reloc.Offset+2] = byte(lo >> 8)
+			cave[reloc.Offset+3] = byte(lo)
+		}
+	}
+
+	linked := binary
+	if end := caveOffset + len(cave); end > len(linked) {
+		grown := make([]byte, end)
+		copy(grown, linked)
+		linked = grown
+	}
+	copy(linked[caveOffset:], cave)
+
+	for _, site := range patches {
+		symOffset, ok := obj.Symbols[site.Symbol]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUndefinedSymbol, site.Symbol)
+		}
+		if site.Offset+4 > len(linked) {
+			return nil, ErrPatchOutOfRange
+		}
+
+		inst := BL(uint(site.Offset), uint(caveOffset+symOffset))
+		copy(linked[site.Offset:], inst[:])
+	}
+
+	return linked, nil
+}
@@ -0,0 +1,67 @@
+package powerpc
+
+import "testing"
+
+func TestLinkBranchInsertion(t *testing.T) {
+	binary := make([]byte, 0x100)
+	obj := Object{
+		Code: Instructions{
+			Padding,
+			Padding,
+		}.Bytes(),
+		Relocs: []Reloc{
+			{Kind: RelBL, Offset: 4, Symbol: "helper"},
+		},
+		Symbols: map[string]int{
+			"entry":  0,
+			"helper": 0,
+		},
+	}
+
+	out, err := Link(binary, 0x100, obj, []CallSite{
+		{Offset: 0x10, Symbol: "entry"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, _, err := DecodeInstruction(out[0x10:0x14])
+	if err != nil || decoded.Mnemonic != "bl" {
+		t.Fatalf("call site not patched: %+v err=%v", decoded, err)
+	}
+
+	decoded, _, err = DecodeInstruction(out[0x104:0x108])
+	if err != nil || decoded.Mnemonic != "bl" {
+		t.Fatalf("relocation not resolved: %+v err=%v", decoded, err)
+	}
+}
+
+func TestLinkHiLo(t *testing.T) {
+	binary := make([]byte, 0x10)
+	lis := LIS(3, 0)
+	ori := ORI(3, 3, 0)
+	obj := Object{
+		Code: Instructions{lis, ori}.Bytes(),
+		Relocs: []Reloc{
+			{Kind: RelHi16, Offset: 0, Symbol: "target"},
+			{Kind: RelLo16, Offset: 4, Symbol: "target"},
+		},
+		Symbols: map[string]int{"target": 0x8000},
+	}
+
+	caveOffset := len(binary)
+	out, err := Link(binary, caveOffset, obj, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := uint32(caveOffset + 0x8000)
+	ha := uint16((want + 0x8000) >> 16)
+	lo := uint16(want & 0xFFFF)
+
+	gotHa := uint16(out[caveOffset+2])<<8 | uint16(out[caveOffset+3])
+	gotLo := uint16(out[caveOffset+6])<<8 | uint16(out[caveOffset+7])
+	if gotHa != ha || gotLo != lo {
+		t.Fatalf("got ha=%04X lo=%04X want ha=%04X lo=%04X", gotHa, gotLo, ha, lo)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+
 	"github.com/logrusorgru/aurora/v3"
 )
 
@@ -30,6 +31,12 @@ type Patch struct {
 
 	// After is an array of the bytes to replace with.
 	After []byte
+
+	// Append, if true, indicates After should be appended to the end of
+	// the binary - growing it - rather than overwritten in place.
+	// AtOffset and Before are ignored when Append is true. This is how a
+	// patch places a code cave the binary does not yet have room for.
+	Append bool
 }
 
 // PatchSet represents multiple patches available to be applied.
@@ -43,19 +50,114 @@ type PatchSet struct {
 	Patches []Patch
 }
 
-// ApplyPatch applies the given patch to the given binary.
-func ApplyPatch(patch Patch, binary []byte) ([]byte, error) {
-	// Print name if present
+// Logger receives notifications as patches and patch sets are applied.
+// It exists so callers embedding this package within a GUI, test suite,
+// or CI job aren't forced to consume console output - ApplyOptions.Logger
+// is nil by default, and ConsoleLogger opts back into the prior behavior.
+type Logger interface {
+	// PatchApplied is called just before an individual patch is applied.
+	PatchApplied(patch Patch)
+	// PatchSetApplied is called just before a patch set's patches are applied.
+	PatchSetApplied(set PatchSet)
+}
+
+// ConsoleLogger reproduces this package's original colorized stdout output.
+type ConsoleLogger struct{}
+
+// PatchApplied prints the patch's name, if present, via aurora.
+func (ConsoleLogger) PatchApplied(patch Patch) {
 	if patch.Name != "" {
 		fmt.Println(" + Applying patch", aurora.Cyan(patch.Name))
 	}
+}
+
+// PatchSetApplied prints the patch set's name, if present, via aurora.
+func (ConsoleLogger) PatchSetApplied(set PatchSet) {
+	if set.Name != "" {
+		fmt.Printf("Handling patch set \"%s\":\n", aurora.Yellow(set.Name))
+	}
+}
+
+// ApplyOptions controls how ApplyPatch, ApplyPatchSet, and ApplyPatchSets
+// behave beyond simply applying bytes.
+type ApplyOptions struct {
+	// Logger, if present, is notified as patches and patch sets are applied.
+	// It is nil by default, so callers get no output unless they opt in
+	// via ConsoleLogger or their own implementation.
+	Logger Logger
+
+	// DryRun, if true, validates every patch without mutating binary.
+	// This also covers the recursive bytes.ReplaceAll form, which
+	// otherwise cannot report failures ahead of time.
+	DryRun bool
+}
+
+// PatchEntry describes the result of applying a single Patch.
+type PatchEntry struct {
+	// Name is the patch's name, if any.
+	Name string
+
+	// Offset is the patch's AtOffset. It is zero for recursively-applied patches,
+	// and the binary's length before appending for appended patches.
+	Offset int
+
+	// BytesBefore holds the bytes present at Offset prior to patching.
+	BytesBefore []byte
+
+	// BytesAfter holds the bytes written to Offset.
+	BytesAfter []byte
+
+	// MatchCount is the number of locations patched. It is always 1 for
+	// patches applied AtOffset, and may be any count for recursively-applied patches.
+	MatchCount int
+
+	// Appended indicates this entry grew the binary via Patch.Append,
+	// rather than overwriting bytes already present at Offset.
+	Appended bool
+
+	// Err holds the error encountered while applying this patch, if any.
+	Err error
+}
+
+// PatchReport is a machine-readable record of every patch applied by
+// ApplyPatch, ApplyPatchSet, or ApplyPatchSets.
+type PatchReport struct {
+	Entries []PatchEntry
+}
+
+// ApplyPatch applies the given patch to the given binary, reporting the
+// result via a PatchEntry rather than printing to stdout.
+func ApplyPatch(patch Patch, binary []byte, opts ApplyOptions) ([]byte, PatchEntry, error) {
+	if opts.Logger != nil {
+		opts.Logger.PatchApplied(patch)
+	}
+
+	entry := PatchEntry{
+		Name:   patch.Name,
+		Offset: patch.AtOffset,
+	}
+
+	if patch.Append {
+		entry.Offset = len(binary)
+		entry.BytesAfter = append([]byte{}, patch.After...)
+		entry.MatchCount = 1
+		entry.Appended = true
+
+		if !opts.DryRun {
+			binary = append(binary, patch.After...)
+		}
+
+		return binary, entry, nil
+	}
 
 	// Ensure consistency
 	if len(patch.Before) != len(patch.After) {
-		return nil, ErrInconsistentPatch
+		entry.Err = ErrInconsistentPatch
+		return binary, entry, entry.Err
 	}
 	if patch.AtOffset != 0 && patch.AtOffset > len(binary) {
-		return nil, ErrPatchOutOfRange
+		entry.Err = ErrPatchOutOfRange
+		return binary, entry, entry.Err
 	}
 
 	// Either Before or After should return the same length.
@@ -66,45 +168,104 @@ func ApplyPatch(patch Patch, binary []byte) ([]byte, error) {
 		// Ensure original bytes are present
 		originalBytes := binary[patch.AtOffset : patch.AtOffset+patchLen]
 		if !bytes.Equal(originalBytes, patch.Before) {
-			return nil, ErrInvalidPatch
+			entry.Err = ErrInvalidPatch
+			return binary, entry, entry.Err
 		}
 
-		// Apply patch at the specified offset
-		copy(binary[patch.AtOffset:], patch.After)
+		entry.BytesBefore = append([]byte{}, originalBytes...)
+		entry.BytesAfter = append([]byte{}, patch.After...)
+		entry.MatchCount = 1
+
+		if !opts.DryRun {
+			copy(binary[patch.AtOffset:], patch.After)
+		}
 	} else {
 		// Recursively apply this patch.
 		// We cannot verify if the original contents are present via this.
-		binary = bytes.ReplaceAll(binary, patch.Before, patch.After)
+		entry.BytesBefore = append([]byte{}, patch.Before...)
+		entry.BytesAfter = append([]byte{}, patch.After...)
+		entry.MatchCount = bytes.Count(binary, patch.Before)
+
+		if !opts.DryRun {
+			binary = bytes.ReplaceAll(binary, patch.Before, patch.After)
+		}
 	}
 
-	return binary, nil
+	return binary, entry, nil
 }
 
-// ApplyPatchSet applies a set of patches to a binary, noting their name.
-func ApplyPatchSet(set PatchSet, binary []byte) ([]byte, error) {
-	if set.Name != "" {
-		fmt.Printf("Handling patch set \"%s\":\n", aurora.Yellow(set.Name))
+// ApplyPatchSet applies a set of patches to a binary, reporting each
+// patch's result via a PatchReport rather than printing to stdout.
+func ApplyPatchSet(set PatchSet, binary []byte, opts ApplyOptions) ([]byte, PatchReport, error) {
+	if opts.Logger != nil {
+		opts.Logger.PatchSetApplied(set)
 	}
 
-	var err error
+	var report PatchReport
 	for _, patch := range set.Patches {
-		binary, err = ApplyPatch(patch, binary)
+		var entry PatchEntry
+		var err error
+		binary, entry, err = ApplyPatch(patch, binary, opts)
+		report.Entries = append(report.Entries, entry)
 		if err != nil {
-			return nil, err
+			return binary, report, err
 		}
 	}
 
-	return binary, err
+	return binary, report, nil
 }
 
-// ApplyPatchSets applies an array of patch sets.
-func ApplyPatchSets(sets []PatchSet, binary []byte) ([]byte, error) {
-	var err error
-	for _, patch := range sets {
-		binary, err = ApplyPatchSet(patch, binary)
+// ApplyPatchSets applies an array of patch sets, reporting every patch's
+// result via a single combined PatchReport.
+func ApplyPatchSets(sets []PatchSet, binary []byte, opts ApplyOptions) ([]byte, PatchReport, error) {
+	var report PatchReport
+	for _, set := range sets {
+		var setReport PatchReport
+		var err error
+		binary, setReport, err = ApplyPatchSet(set, binary, opts)
+		report.Entries = append(report.Entries, setReport.Entries...)
 		if err != nil {
-			return nil, err
+			return binary, report, err
+		}
+	}
+
+	return binary, report, nil
+}
+
+// UndoPatch reverses every successfully-applied entry within report,
+// walking it in reverse order, and returns the restored binary.
+func UndoPatch(report PatchReport, binary []byte) ([]byte, error) {
+	for i := len(report.Entries) - 1; i >= 0; i-- {
+		entry := report.Entries[i]
+		if entry.Err != nil {
+			// This patch was never applied - nothing to undo.
+			continue
+		}
+
+		if entry.Appended {
+			if entry.Offset+len(entry.BytesAfter) > len(binary) {
+				return nil, ErrInvalidPatch
+			}
+			currentBytes := binary[entry.Offset : entry.Offset+len(entry.BytesAfter)]
+			if !bytes.Equal(currentBytes, entry.BytesAfter) {
+				return nil, ErrInvalidPatch
+			}
+			binary = binary[:entry.Offset]
+			continue
 		}
+
+		if entry.MatchCount == 1 && entry.Offset != 0 {
+			currentBytes := binary[entry.Offset : entry.Offset+len(entry.BytesAfter)]
+			if !bytes.Equal(currentBytes, entry.BytesAfter) {
+				return nil, ErrInvalidPatch
+			}
+			copy(binary[entry.Offset:], entry.BytesBefore)
+			continue
+		}
+
+		// Recursively-applied patches were not tied to a single offset -
+		// restore them the same way they were applied, in reverse.
+		binary = bytes.ReplaceAll(binary, entry.BytesAfter, entry.BytesBefore)
 	}
 
 	return binary, nil
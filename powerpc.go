@@ -1,5 +1,7 @@
 package powerpc
 
+//go:generate go run ./internal/gen -csv opcodes.csv -out zinstructions.go
+
 import "encoding/binary"
 
 // fourByte returns 4 bytes, suitable for the given length.
@@ -47,12 +49,6 @@ func BLR() Instruction {
 	return [4]byte{0x4E, 0x80, 0x00, 0x20}
 }
 
-// CRXOR represents a common use of CRXOR on PowerPC.
-// TODO: actually implement
-func CRXOR() Instruction {
-	return [4]byte{0x4c, 0xc6, 0x31, 0x82}
-}
-
 // ADDI represents the addi PowerPC instruction.
 func ADDI(rT Register, rA Register, value uint16) Instruction {
 	return EncodeInstrDForm(14, rT, rA, value)
@@ -64,9 +60,9 @@ func LI(rT Register, value uint16) Instruction {
 }
 
 // SUBI represents the subi mnemonic on PowerPC.
-// TODO: handle negative values properly?
+// subi has no direct opcode of its own - it is addi with its immediate negated.
 func SUBI(rT Register, rA Register, value uint16) Instruction {
-	return ADDI(rT, 0, -value)
+	return ADDI(rT, rA, uint16(-int16(value)))
 }
 
 // ADDIS represents the addis PowerPC instruction.
@@ -120,10 +116,12 @@ func CMPWI(rA Register, value uint16) Instruction {
 	return EncodeInstrDForm(11, 0, rA, value)
 }
 
-// SYNC is a hack, hardcoding sync 0.
-// TODO(spotlightishere): actually encode this
-func SYNC() Instruction {
-	return [4]byte{0x7c, 0x00, 0x04, 0xac}
+// SYNC represents the sync PowerPC instruction.
+// l selects the sync's scope (0 for heavyweight sync, 1 for lwsync, 2 for ptesync).
+func SYNC(l uint8) Instruction {
+	var result Instruction
+	binary.BigEndian.PutUint32(result[:], uint32(31)<<26|uint32(l&0x3)<<21|598<<1)
+	return result
 }
 
 // MTSPR represents the mtspr PowerPC instruction.
@@ -143,13 +141,14 @@ func STWU(rS Register, rA Register, offset uint16) Instruction {
 
 // calcDestination determines the proper offset from a given
 // calling address and target address.
+//
+// Both forward and backward branches are supported: the result is masked
+// down to the 24-bit signed displacement PowerPC branch instructions
+// actually encode, so negative offsets wrap around correctly rather than
+// overflowing into the instruction's opcode bits.
 func calcDestination(from uint, target uint) [3]byte {
-	// TODO(spotlightishere): Handle negative offsets properly
-	offset := target - from
-
-	// Sign-extend by two bytes
-	calc := uint32(offset >> 2)
-	return uint24(calc)
+	calc := (int32(target) - int32(from)) >> 2 & 0x00FFFFFF
+	return uint24(uint32(calc))
 }
 
 // BL represents the bl PowerPC instruction.
@@ -0,0 +1,39 @@
+// Code generated by go generate from opcodes.csv; DO NOT EDIT.
+
+package powerpc
+
+// MULLI represents the mulli PowerPC instruction.
+// It multiplies the contents of a register by a signed immediate value.
+func MULLI(rT Register, rA Register, value int16) Instruction {
+	return EncodeInstrDForm(7, rT, rA, uint16(value))
+}
+
+// CRXOR represents the crxor PowerPC instruction.
+// It performs a bitwise XOR of two condition register bits.
+func CRXOR(crbD uint8, crbA uint8, crbB uint8) Instruction {
+	return EncodeInstrXLForm(19, crbD, crbA, crbB, 193, false)
+}
+
+// BCLR represents the bclr PowerPC instruction.
+// It conditionally branches to the address held in the link register.
+func BCLR(bo uint8, bi uint8, lk bool) Instruction {
+	return EncodeInstrXLForm(19, bo, bi, 0, 16, lk)
+}
+
+// BCCTR represents the bcctr PowerPC instruction.
+// It conditionally branches to the address held in the count register.
+func BCCTR(bo uint8, bi uint8, lk bool) Instruction {
+	return EncodeInstrXLForm(19, bo, bi, 0, 528, lk)
+}
+
+// RLWINM represents the rlwinm PowerPC instruction.
+// It rotates a register left and masks the result into another register.
+func RLWINM(rS Register, rA Register, sh uint8, mb uint8, me uint8, rc bool) Instruction {
+	return EncodeInstrMForm(21, rS, rA, sh, mb, me, rc)
+}
+
+// MTCRF represents the mtcrf PowerPC instruction.
+// It moves a register into the condition register fields selected by a mask.
+func MTCRF(rS Register, crm uint8) Instruction {
+	return EncodeInstrXFXCRMForm(31, rS, crm, 144)
+}